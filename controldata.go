@@ -0,0 +1,127 @@
+package pg_ctl
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// LSN is a write-ahead log location, printed by Postgres tools as two hex numbers separated by a
+// slash ("0/16ADB50"): the high 32 bits and the low 32 bits of the byte offset into the WAL.
+type LSN uint64
+
+// ParseLSN parses the "X/Y" hex format pg_controldata and friends use for WAL locations.
+func ParseLSN(s string) (LSN, error) {
+	hi, lo, ok := strings.Cut(s, "/")
+	if !ok {
+		return 0, fmt.Errorf("pg_ctl: invalid LSN %q", s)
+	}
+	hiBits, err := strconv.ParseUint(hi, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("pg_ctl: invalid LSN %q", s)
+	}
+	loBits, err := strconv.ParseUint(lo, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("pg_ctl: invalid LSN %q", s)
+	}
+	return LSN(hiBits<<32 | loBits), nil
+}
+
+// String formats the LSN in the same "X/Y" hex format ParseLSN accepts.
+func (l LSN) String() string {
+	return fmt.Sprintf("%X/%X", uint32(l>>32), uint32(l))
+}
+
+// ControlData is a typed view of `pg_controldata`'s output, covering the fields most useful for
+// shutdown-checkpoint detection and failover decisions (as Patroni does, for example: confirming
+// a primary was cleanly shut down before promoting a replica).
+type ControlData struct {
+	DatabaseSystemIdentifier uint64
+
+	// ClusterState is one of pg_controldata's cluster-state strings, e.g. "in production",
+	// "shut down", or "in archive recovery".
+	ClusterState string
+
+	LatestCheckpointLocation LSN
+	REDOLocation             LSN
+	TimeLineID               int
+
+	WALLevel       string
+	MaxConnections int
+
+	BlockSize      int
+	WALSegmentSize int
+
+	CatalogVersionNumber int
+
+	// Raw holds every key/value pair pg_controldata printed, label verbatim, for fields this
+	// struct doesn't surface directly.
+	Raw map[string]string
+}
+
+// ControlData shells out to `pg_controldata -D <dataDir>` and parses its output.
+func (p *Controller) ControlData(ctx context.Context) (*ControlData, error) {
+	binary, err := p.controlDataBinaryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, binary, "-D", p.dataDir)
+	cmd.Env = append(os.Environ(), "LANG=C", "LC_ALL=C")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("pg_ctl: pg_controldata failed: %v", err)
+	}
+	return parseControlData(out), nil
+}
+
+// controlDataBinaryPath resolves pg_controldata from the same directory as the Controller's
+// resolved pg_ctl binary first, so a Controller pinned to one PostgreSQL install via WithBinary or
+// WithBinDir doesn't end up reading another install's pg_controldata off $PATH when multiple
+// major versions are present. Only if that fails does it fall back to the generic search.
+func (p *Controller) controlDataBinaryPath() (string, error) {
+	if pgCtl, err := p.binaryPath(); err == nil {
+		candidate := filepath.Join(filepath.Dir(pgCtl), "pg_controldata")
+		if isExecutableFile(candidate) {
+			return candidate, nil
+		}
+	}
+	return lookupPostgresBinary("pg_controldata", "PG_CONTROLDATA")
+}
+
+func parseControlData(out []byte) *ControlData {
+	raw := make(map[string]string)
+	for _, line := range strings.Split(string(out), "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		raw[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	cd := &ControlData{Raw: raw}
+	cd.DatabaseSystemIdentifier, _ = strconv.ParseUint(stripThousandsSeparators(raw["Database system identifier"]), 10, 64)
+	cd.ClusterState = raw["Database cluster state"]
+	cd.LatestCheckpointLocation, _ = ParseLSN(raw["Latest checkpoint location"])
+	cd.REDOLocation, _ = ParseLSN(raw["Latest checkpoint's REDO location"])
+	cd.TimeLineID, _ = strconv.Atoi(stripThousandsSeparators(raw["Latest checkpoint's TimeLineID"]))
+	cd.WALLevel = raw["wal_level setting"]
+	cd.MaxConnections, _ = strconv.Atoi(stripThousandsSeparators(raw["max_connections setting"]))
+	cd.BlockSize, _ = strconv.Atoi(stripThousandsSeparators(raw["Database block size"]))
+	cd.WALSegmentSize, _ = strconv.Atoi(stripThousandsSeparators(raw["Bytes per WAL segment"]))
+	cd.CatalogVersionNumber, _ = strconv.Atoi(stripThousandsSeparators(raw["Catalog version number"]))
+	return cd
+}
+
+// stripThousandsSeparators removes the comma and space groupings some locales use in
+// pg_controldata's numeric output, so values parse even when a caller can't guarantee LANG=C
+// reached the subprocess (e.g. a libc that ignores it for this locale category).
+func stripThousandsSeparators(s string) string {
+	s = strings.ReplaceAll(s, ",", "")
+	s = strings.ReplaceAll(s, " ", "")
+	return s
+}
@@ -0,0 +1,91 @@
+package pg_ctl
+
+import "testing"
+
+func TestParseLSN(t *testing.T) {
+	cases := []struct {
+		s    string
+		want LSN
+	}{
+		{"0/16ADB50", 0x16ADB50},
+		{"3/0", 0x300000000},
+		{"0/0", 0},
+	}
+	for _, c := range cases {
+		got, err := ParseLSN(c.s)
+		if err != nil {
+			t.Errorf("ParseLSN(%q) returned error: %v", c.s, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseLSN(%q) = %#x, want %#x", c.s, uint64(got), uint64(c.want))
+		}
+	}
+
+	if _, err := ParseLSN("not-an-lsn"); err == nil {
+		t.Error("ParseLSN(\"not-an-lsn\") returned nil error, want error")
+	}
+}
+
+func TestLSNStringRoundTrips(t *testing.T) {
+	for _, s := range []string{"0/16ADB50", "3/0", "FFFFFFFF/1"} {
+		lsn, err := ParseLSN(s)
+		if err != nil {
+			t.Fatalf("ParseLSN(%q): %v", s, err)
+		}
+		if got := lsn.String(); got != s {
+			t.Errorf("ParseLSN(%q).String() = %q, want %q", s, got, s)
+		}
+	}
+}
+
+func TestParseControlData(t *testing.T) {
+	out := []byte(`pg_control version number:            1300
+Catalog version number:               202201106
+Database system identifier:           7,123,456,789,012,345,678
+Database cluster state:               in production
+Latest checkpoint location:           0/16ADB50
+Latest checkpoint's REDO location:    0/16ADB18
+Latest checkpoint's TimeLineID:       1
+Database block size:                  8192
+Bytes per WAL segment:                16777216
+max_connections setting:              100
+wal_level setting:                    replica
+`)
+
+	cd := parseControlData(out)
+
+	if cd.DatabaseSystemIdentifier != 7123456789012345678 {
+		t.Errorf("DatabaseSystemIdentifier = %d, want 7123456789012345678", cd.DatabaseSystemIdentifier)
+	}
+	if cd.ClusterState != "in production" {
+		t.Errorf("ClusterState = %q, want %q", cd.ClusterState, "in production")
+	}
+	if want, _ := ParseLSN("0/16ADB50"); cd.LatestCheckpointLocation != want {
+		t.Errorf("LatestCheckpointLocation = %v, want %v", cd.LatestCheckpointLocation, want)
+	}
+	if want, _ := ParseLSN("0/16ADB18"); cd.REDOLocation != want {
+		t.Errorf("REDOLocation = %v, want %v", cd.REDOLocation, want)
+	}
+	if cd.TimeLineID != 1 {
+		t.Errorf("TimeLineID = %d, want 1", cd.TimeLineID)
+	}
+	if cd.BlockSize != 8192 {
+		t.Errorf("BlockSize = %d, want 8192", cd.BlockSize)
+	}
+	if cd.WALSegmentSize != 16777216 {
+		t.Errorf("WALSegmentSize = %d, want 16777216", cd.WALSegmentSize)
+	}
+	if cd.MaxConnections != 100 {
+		t.Errorf("MaxConnections = %d, want 100", cd.MaxConnections)
+	}
+	if cd.WALLevel != "replica" {
+		t.Errorf("WALLevel = %q, want %q", cd.WALLevel, "replica")
+	}
+	if cd.CatalogVersionNumber != 202201106 {
+		t.Errorf("CatalogVersionNumber = %d, want 202201106", cd.CatalogVersionNumber)
+	}
+	if cd.Raw["Database cluster state"] != "in production" {
+		t.Errorf("Raw[\"Database cluster state\"] = %q, want %q", cd.Raw["Database cluster state"], "in production")
+	}
+}
@@ -11,17 +11,78 @@ import (
 	"bytes"
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 	"syscall"
 )
 
+// pg_ctl_bin is the legacy hard-coded fallback, kept for GPDB installs that don't match any of
+// LookupPgCtl's search locations. Prefer WithBinary, WithBinDir, or $PGBIN/$PG_CTL.
 var pg_ctl_bin = "/usr/local/gpdb/bin/pg_ctl"
 
 // Controller holds information necessary for pg_ctl calls
 type Controller struct {
 	dataDir string
+
+	// binary is an explicit pg_ctl path from WithBinary/WithBinDir. Empty means resolve lazily
+	// via LookupPgCtl, falling back to pg_ctl_bin.
+	binary string
+
+	// pgVersionMajor is an explicit major version from WithPGVersion. Zero means detect it from
+	// PG_VERSION or `pg_ctl --version`.
+	pgVersionMajor int
+
+	// logPath is an explicit log file from WithLogPath. Empty means resolve lazily from
+	// postgresql.conf's log_directory/log_filename.
+	logPath string
+}
+
+// ControllerOption configures a Controller constructed by NewController.
+type ControllerOption func(*Controller)
+
+// WithBinary pins the Controller to an explicit pg_ctl executable, skipping auto-detection.
+func WithBinary(path string) ControllerOption {
+	return func(c *Controller) {
+		c.binary = path
+	}
+}
+
+// WithBinDir pins the Controller to the pg_ctl executable found in dir, skipping auto-detection.
+func WithBinDir(dir string) ControllerOption {
+	return func(c *Controller) {
+		c.binary = filepath.Join(dir, "pg_ctl")
+	}
+}
+
+// WithPGVersion pins the Controller's server major version, skipping detection via PG_VERSION or
+// `pg_ctl --version`. Useful when dataDir doesn't exist yet, e.g. before the first InitDB.
+func WithPGVersion(major int) ControllerOption {
+	return func(c *Controller) {
+		c.pgVersionMajor = major
+	}
+}
+
+// WithLogPath pins TailLog and WaitForLogPattern to an explicit log file, skipping the
+// postgresql.conf-based lookup. Useful when log_filename contains an strftime placeholder pg_ctl
+// can't resolve on its own, or logs are redirected outside log_directory entirely.
+func WithLogPath(path string) ControllerOption {
+	return func(c *Controller) {
+		c.logPath = path
+	}
+}
+
+// binaryPath resolves the pg_ctl executable to invoke: an explicit WithBinary/WithBinDir
+// override, else LookupPgCtl's auto-detection, else the legacy pg_ctl_bin default.
+func (p *Controller) binaryPath() (string, error) {
+	if p.binary != "" {
+		return p.binary, nil
+	}
+	if path, err := LookupPgCtl(); err == nil {
+		return path, nil
+	}
+	return pg_ctl_bin, nil
 }
 
 // Status is a convenient representation of the results from `pg_ctl status`
@@ -55,22 +116,33 @@ type Status struct {
 }
 
 // NewController is a factory, making a Controller that can be used for pg_ctl calls against a particular dataDir
-func NewController(dataDir string) *Controller {
-	return &Controller{
+//
+// By default the pg_ctl binary is auto-detected via LookupPgCtl; pass WithBinary or WithBinDir to
+// override that.
+func NewController(dataDir string, opts ...ControllerOption) *Controller {
+	c := &Controller{
 		dataDir: dataDir,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // Status executes a vanilla `pg_ctl status`
 //
 // it waits for the command to finish before returning
 func (p *Controller) Status() (Status, error) {
-	cmd := exec.Command(pg_ctl_bin, "status", "-w", "-D", p.dataDir)
+	binary, err := p.binaryPath()
+	if err != nil {
+		return Status{ErrorCode: 127}, err
+	}
+	cmd := exec.Command(binary, "status", "-w", "-D", p.dataDir)
 	var outbuf, errbuf bytes.Buffer
 	cmd.Stdout = &outbuf
 	cmd.Stderr = &errbuf
 
-	err := cmd.Run()
+	err = cmd.Run()
 
 	var errorCode int
 	if err != nil {
@@ -124,8 +196,12 @@ func (p *Controller) Status() (Status, error) {
 //
 // Deprecated: Use Status.IsServerRunning instead
 func (p *Controller) IsStarted() (bool, error) {
-	cmd := exec.Command(pg_ctl_bin, "status", "-w", "-D", p.dataDir, "-o", "-c unix_socket_directories=/tmp")
-	_, err := cmd.CombinedOutput()
+	binary, err := p.binaryPath()
+	if err != nil {
+		return false, err
+	}
+	cmd := exec.Command(binary, "status", "-w", "-D", p.dataDir, "-o", "-c unix_socket_directories=/tmp")
+	_, err = cmd.CombinedOutput()
 	if err != nil {
 		if _, ok := err.(*exec.ExitError); ok {
 			status := cmd.ProcessState.Sys().(syscall.WaitStatus).ExitStatus()
@@ -0,0 +1,126 @@
+package pg_ctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// Initializer wraps initdb, the tool that creates a new PostgreSQL data directory.
+type Initializer struct {
+	// binary is an explicit initdb path from WithInitdbBinary. Empty means resolve lazily via
+	// LookupInitdb.
+	binary string
+}
+
+// InitializerOption configures an Initializer constructed by NewInitializer.
+type InitializerOption func(*Initializer)
+
+// WithInitdbBinary pins the Initializer to an explicit initdb executable, skipping
+// auto-detection.
+func WithInitdbBinary(path string) InitializerOption {
+	return func(i *Initializer) {
+		i.binary = path
+	}
+}
+
+// NewInitializer is a factory, making an Initializer that can be used to run initdb.
+//
+// By default the initdb binary is auto-detected via LookupInitdb; pass WithInitdbBinary to
+// override that.
+func NewInitializer(opts ...InitializerOption) *Initializer {
+	i := &Initializer{}
+	for _, opt := range opts {
+		opt(i)
+	}
+	return i
+}
+
+func (i *Initializer) binaryPath() (string, error) {
+	if i.binary != "" {
+		return i.binary, nil
+	}
+	return LookupInitdb()
+}
+
+// InitOptions configures Init.
+type InitOptions struct {
+	// DataDir is the target data directory. Required.
+	DataDir string
+
+	// Encoding sets initdb's -E/--encoding flag, e.g. "UTF8".
+	Encoding string
+
+	// Locale sets initdb's --locale flag.
+	Locale string
+
+	// AuthLocal sets initdb's --auth-local flag, the auth method for Unix-socket connections.
+	AuthLocal string
+
+	// AuthHost sets initdb's --auth-host flag, the auth method for TCP connections.
+	AuthHost string
+
+	// Username sets initdb's -U/--username flag, the name of the superuser to create.
+	Username string
+
+	// PwFile sets initdb's --pwfile flag, a file to read the superuser's password from.
+	PwFile string
+
+	// NoSync sets initdb's --no-sync flag, skipping fsync for faster throwaway clusters.
+	NoSync bool
+
+	// WalDir sets initdb's --waldir flag, relocating the write-ahead log.
+	WalDir string
+
+	// ExtraArgs are appended verbatim to the initdb invocation.
+	ExtraArgs []string
+}
+
+// Init runs initdb against opts.DataDir, streaming stdout and stderr to the given writers as the
+// command runs. Either writer may be nil to discard that stream.
+func (i *Initializer) Init(ctx context.Context, opts InitOptions, stdout, stderr io.Writer) error {
+	if opts.DataDir == "" {
+		return fmt.Errorf("pg_ctl: InitOptions.DataDir is required")
+	}
+
+	binary, err := i.binaryPath()
+	if err != nil {
+		return err
+	}
+
+	args := []string{"-D", opts.DataDir}
+	if opts.Encoding != "" {
+		args = append(args, "-E", opts.Encoding)
+	}
+	if opts.Locale != "" {
+		args = append(args, "--locale", opts.Locale)
+	}
+	if opts.AuthLocal != "" {
+		args = append(args, "--auth-local", opts.AuthLocal)
+	}
+	if opts.AuthHost != "" {
+		args = append(args, "--auth-host", opts.AuthHost)
+	}
+	if opts.Username != "" {
+		args = append(args, "-U", opts.Username)
+	}
+	if opts.PwFile != "" {
+		args = append(args, "--pwfile", opts.PwFile)
+	}
+	if opts.NoSync {
+		args = append(args, "--no-sync")
+	}
+	if opts.WalDir != "" {
+		args = append(args, "--waldir", opts.WalDir)
+	}
+	args = append(args, opts.ExtraArgs...)
+
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pg_ctl: initdb failed: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package pg_ctl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFakeInitdb writes a shell script standing in for initdb: it honors -D (and records every
+// argument it was called with, to argsLogPath) and writes a PG_VERSION file so downstream
+// version-matching logic has something real to read.
+func writeFakeInitdb(t *testing.T, argsLogPath, pgVersion string) string {
+	t.Helper()
+	script := filepath.Join(t.TempDir(), "fake-initdb")
+	contents := `#!/bin/sh
+echo "$@" >> "` + argsLogPath + `"
+datadir=""
+while [ "$#" -gt 0 ]; do
+  case "$1" in
+    -D) shift; datadir="$1" ;;
+  esac
+  shift
+done
+mkdir -p "$datadir"
+echo "` + pgVersion + `" > "$datadir/PG_VERSION"
+`
+	if err := os.WriteFile(script, []byte(contents), 0755); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return script
+}
+
+func TestInitializerInitRunsInitdbWithFlags(t *testing.T) {
+	dir := t.TempDir()
+	dataDir := filepath.Join(dir, "data")
+	argsLog := filepath.Join(dir, "args.log")
+
+	initdb := writeFakeInitdb(t, argsLog, "16")
+	i := NewInitializer(WithInitdbBinary(initdb))
+
+	err := i.Init(context.Background(), InitOptions{
+		DataDir:  dataDir,
+		Encoding: "UTF8",
+		Locale:   "C",
+		NoSync:   true,
+	}, nil, nil)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dataDir, "PG_VERSION")); err != nil {
+		t.Errorf("PG_VERSION was not created: %v", err)
+	}
+
+	logged, err := os.ReadFile(argsLog)
+	if err != nil {
+		t.Fatalf("ReadFile(args.log): %v", err)
+	}
+	for _, want := range []string{"-D", dataDir, "-E", "UTF8", "--locale", "C", "--no-sync"} {
+		if !strings.Contains(string(logged), want) {
+			t.Errorf("initdb args %q missing %q", logged, want)
+		}
+	}
+}
+
+func TestInitializerInitRequiresDataDir(t *testing.T) {
+	i := NewInitializer()
+	if err := i.Init(context.Background(), InitOptions{}, nil, nil); err == nil {
+		t.Error("Init with empty DataDir returned nil error, want error")
+	}
+}
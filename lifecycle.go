@@ -0,0 +1,351 @@
+package pg_ctl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrStartFailed is returned by Start when the postmaster could not be launched at all.
+//
+// On PostgreSQL 10+, `pg_ctl start -w` returns a non-zero exit code both for this case and for
+// ErrStartedNotReady, so Start inspects postmaster.pid and probes the listening socket to tell
+// them apart.
+var ErrStartFailed = errors.New("pg_ctl: start failed")
+
+// ErrStartedNotReady is returned by Start when the postmaster launched successfully but did not
+// report readiness before the timeout elapsed. Callers may treat this as retryable: poll Status
+// or call WaitForLogPattern rather than assuming the server is down.
+var ErrStartedNotReady = errors.New("pg_ctl: server started but did not become ready in time")
+
+// StopMode selects the shutdown mode passed to `pg_ctl stop`.
+type StopMode string
+
+const (
+	StopSmart     StopMode = "smart"
+	StopFast      StopMode = "fast"
+	StopImmediate StopMode = "immediate"
+)
+
+// StartOptions configures Start and the start half of Restart.
+type StartOptions struct {
+	// Timeout bounds how long Start waits for the server to report readiness. If zero, Start
+	// uses ctx's deadline if any, otherwise a package default.
+	Timeout time.Duration
+
+	// Options, if non-empty, is passed as `-o "<Options>"` to the postmaster.
+	Options string
+
+	// ExtraArgs are appended verbatim to the `pg_ctl start` invocation.
+	ExtraArgs []string
+}
+
+// StartResult reports the outcome of Start, analogous to Status.
+type StartResult struct {
+	ErrorCode int
+	RawStdOut string
+	RawStdErr string
+
+	// Ready is true only once readiness was independently confirmed (not merely inferred from
+	// pg_ctl's exit code, which is unreliable pre-10).
+	Ready bool
+
+	// Pid is the postmaster's PID as read from postmaster.pid. It is set whenever that file held a
+	// valid PID, even if the server never became ready, and is 0 if no pid file was found.
+	Pid int
+}
+
+// StopResult reports the outcome of Stop, analogous to Status.
+type StopResult struct {
+	ErrorCode int
+	RawStdOut string
+	RawStdErr string
+	Stopped   bool
+}
+
+// ReloadResult reports the outcome of Reload, analogous to Status.
+type ReloadResult struct {
+	ErrorCode int
+	RawStdOut string
+	RawStdErr string
+	Reloaded  bool
+}
+
+// PromoteResult reports the outcome of Promote, analogous to Status.
+type PromoteResult struct {
+	ErrorCode int
+	RawStdOut string
+	RawStdErr string
+	Promoted  bool
+}
+
+const defaultReadinessTimeout = 60 * time.Second
+const readinessPollInterval = 200 * time.Millisecond
+
+// Start executes `pg_ctl start -w` against the Controller's dataDir, then independently confirms
+// readiness so callers get a reliable result on every supported PostgreSQL version.
+//
+// pg_ctl's own exit code cannot be trusted for this: versions before PostgreSQL 10 return 0 as
+// soon as the postmaster forks, even if it never accepts connections, while 10+ returns non-zero
+// both when the postmaster fails to launch and when it launches but isn't ready by the time -w's
+// internal timeout expires. Start tells these apart by polling postmaster.pid and, failing that,
+// probing the configured socket/port directly. A failed launch (no pid file ever appears) returns
+// ErrStartFailed; a launch that never reports ready returns ErrStartedNotReady.
+func (p *Controller) Start(ctx context.Context, opts StartOptions) (StartResult, error) {
+	timeout := opts.Timeout
+	if timeout == 0 {
+		if deadline, ok := ctx.Deadline(); ok {
+			timeout = time.Until(deadline)
+		} else {
+			timeout = defaultReadinessTimeout
+		}
+	}
+
+	major, _ := p.majorVersion()
+
+	binary, err := p.binaryPath()
+	if err != nil {
+		return StartResult{}, err
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	args := []string{"start", "-w", "-D", p.dataDir, "-t", strconv.Itoa(int(timeout.Seconds()))}
+	if opts.Options != "" {
+		args = append(args, "-o", opts.Options)
+	}
+	args = append(args, opts.ExtraArgs...)
+
+	cmd := exec.CommandContext(ctx, binary, args...)
+	var outbuf, errbuf bytes.Buffer
+	cmd.Stdout = &outbuf
+	cmd.Stderr = &errbuf
+	runErr := cmd.Run()
+
+	errorCode := exitCode(runErr)
+
+	// pg_ctl's own -t wait already ate into the deadline; waitForReady only gets what's left, so
+	// Start's total wall-clock time stays bounded by timeout instead of doubling it.
+	ready, pid := waitForReady(ctx, p.dataDir, time.Until(deadline))
+	result := StartResult{
+		ErrorCode: errorCode,
+		RawStdOut: outbuf.String(),
+		RawStdErr: errbuf.String(),
+		Ready:     ready,
+		Pid:       pid,
+	}
+
+	if ready {
+		return result, nil
+	}
+
+	// Pre-10, a zero exit code without readiness still means the launch attempt completed; we
+	// only have the readiness probe to go on either way.
+	if major >= 10 && errorCode == 0 {
+		return result, ErrStartedNotReady
+	}
+	if pid == 0 {
+		return result, ErrStartFailed
+	}
+	return result, ErrStartedNotReady
+}
+
+// Stop executes `pg_ctl stop` in the given mode, waiting up to timeout for the postmaster to
+// exit.
+func (p *Controller) Stop(ctx context.Context, mode StopMode, timeout time.Duration) (StopResult, error) {
+	if mode == "" {
+		mode = StopFast
+	}
+	args := []string{"stop", "-w", "-D", p.dataDir, "-m", string(mode)}
+	if timeout > 0 {
+		args = append(args, "-t", strconv.Itoa(int(timeout.Seconds())))
+	}
+
+	binary, err := p.binaryPath()
+	if err != nil {
+		return StopResult{}, err
+	}
+	cmd := exec.CommandContext(ctx, binary, args...)
+	var outbuf, errbuf bytes.Buffer
+	cmd.Stdout = &outbuf
+	cmd.Stderr = &errbuf
+	runErr := cmd.Run()
+
+	errorCode := exitCode(runErr)
+	result := StopResult{
+		ErrorCode: errorCode,
+		RawStdOut: outbuf.String(),
+		RawStdErr: errbuf.String(),
+		Stopped:   errorCode == 0,
+	}
+	if errorCode != 0 {
+		return result, fmt.Errorf("pg_ctl: stop failed: %s", strings.TrimSpace(result.RawStdErr))
+	}
+	return result, nil
+}
+
+// Restart stops and then starts the server, applying Start's version-aware readiness semantics
+// to the start half.
+func (p *Controller) Restart(ctx context.Context, mode StopMode, opts StartOptions) (StartResult, error) {
+	if _, err := p.Stop(ctx, mode, opts.Timeout); err != nil {
+		return StartResult{}, fmt.Errorf("pg_ctl: restart: %w", err)
+	}
+	return p.Start(ctx, opts)
+}
+
+// Reload executes `pg_ctl reload`, asking the postmaster to re-read its configuration files
+// without restarting.
+func (p *Controller) Reload(ctx context.Context) (ReloadResult, error) {
+	binary, err := p.binaryPath()
+	if err != nil {
+		return ReloadResult{}, err
+	}
+	cmd := exec.CommandContext(ctx, binary, "reload", "-D", p.dataDir)
+	var outbuf, errbuf bytes.Buffer
+	cmd.Stdout = &outbuf
+	cmd.Stderr = &errbuf
+	runErr := cmd.Run()
+
+	errorCode := exitCode(runErr)
+	result := ReloadResult{
+		ErrorCode: errorCode,
+		RawStdOut: outbuf.String(),
+		RawStdErr: errbuf.String(),
+		Reloaded:  errorCode == 0,
+	}
+	if errorCode != 0 {
+		return result, fmt.Errorf("pg_ctl: reload failed: %s", strings.TrimSpace(result.RawStdErr))
+	}
+	return result, nil
+}
+
+// Promote executes `pg_ctl promote -w`, bringing a standby out of recovery.
+func (p *Controller) Promote(ctx context.Context) (PromoteResult, error) {
+	binary, err := p.binaryPath()
+	if err != nil {
+		return PromoteResult{}, err
+	}
+	cmd := exec.CommandContext(ctx, binary, "promote", "-w", "-D", p.dataDir)
+	var outbuf, errbuf bytes.Buffer
+	cmd.Stdout = &outbuf
+	cmd.Stderr = &errbuf
+	runErr := cmd.Run()
+
+	errorCode := exitCode(runErr)
+	result := PromoteResult{
+		ErrorCode: errorCode,
+		RawStdOut: outbuf.String(),
+		RawStdErr: errbuf.String(),
+		Promoted:  errorCode == 0,
+	}
+	if errorCode != 0 {
+		return result, fmt.Errorf("pg_ctl: promote failed: %s", strings.TrimSpace(result.RawStdErr))
+	}
+	return result, nil
+}
+
+// exitCode extracts a Unix exit status from the error returned by cmd.Run(), mirroring Status's
+// handling of ExitError. It returns 0 for a nil err and 127 when the status can't be determined.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	exiterr, ok := err.(*exec.ExitError)
+	if !ok {
+		return 127
+	}
+	waitStatus, ok := exiterr.Sys().(syscall.WaitStatus)
+	if !ok {
+		return 127
+	}
+	return waitStatus.ExitStatus()
+}
+
+// waitForReady polls for readiness until timeout elapses or ctx is done. It first watches
+// postmaster.pid for a valid PID and, on servers new enough to write one, a trailing "ready"
+// status line; if that doesn't resolve things it falls back to probing the postmaster's
+// listening socket directly, since postmaster.pid's status line isn't written on all versions
+// pg_ctl supports.
+func waitForReady(ctx context.Context, dataDir string, timeout time.Duration) (ready bool, pid int) {
+	deadline := time.Now().Add(timeout)
+	pidPath := filepath.Join(dataDir, "postmaster.pid")
+
+	for {
+		if p, status, ok := readPostmasterPid(pidPath); ok {
+			pid = p
+			if status == "ready" || probeSocket(dataDir, p) {
+				return true, pid
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return false, pid
+		}
+		select {
+		case <-ctx.Done():
+			return false, pid
+		case <-time.After(readinessPollInterval):
+		}
+	}
+}
+
+// readPostmasterPid parses postmaster.pid, returning the postmaster's PID and, if present, the
+// status line PostgreSQL 10+ appends as the file's 8th line ("ready" or "standby").
+func readPostmasterPid(path string) (pid int, status string, ok bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, "", false
+	}
+	lines := strings.Split(string(data), "\n")
+	if len(lines) < 1 {
+		return 0, "", false
+	}
+	pid, err = strconv.Atoi(strings.TrimSpace(lines[0]))
+	if err != nil {
+		return 0, "", false
+	}
+	if len(lines) >= 8 {
+		status = strings.TrimSpace(lines[7])
+	}
+	return pid, status, true
+}
+
+// probeSocket attempts a direct connection to the postmaster's listening port or Unix socket, as
+// read from postmaster.pid's port and socket-directory lines. It returns true as soon as
+// something is listening, for servers too old to write an explicit readiness status.
+func probeSocket(dataDir string, pid int) bool {
+	data, err := os.ReadFile(filepath.Join(dataDir, "postmaster.pid"))
+	if err != nil {
+		return false
+	}
+	lines := strings.Split(string(data), "\n")
+	if len(lines) < 5 {
+		return false
+	}
+	port := strings.TrimSpace(lines[3])
+	sockDir := strings.TrimSpace(lines[4])
+
+	if sockDir != "" {
+		sockPath := filepath.Join(sockDir, fmt.Sprintf(".s.PGSQL.%s", port))
+		if conn, err := net.DialTimeout("unix", sockPath, time.Second); err == nil {
+			conn.Close()
+			return true
+		}
+	}
+	if port != "" {
+		if conn, err := net.DialTimeout("tcp", net.JoinHostPort("127.0.0.1", port), time.Second); err == nil {
+			conn.Close()
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package pg_ctl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadPostmasterPid(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "postmaster.pid")
+
+	content := "12345\n/data\n1234567890\n5432\n/tmp\n\n0\nready\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	pid, status, ok := readPostmasterPid(path)
+	if !ok {
+		t.Fatal("readPostmasterPid(...) = (_, _, false), want ok")
+	}
+	if pid != 12345 {
+		t.Errorf("pid = %d, want 12345", pid)
+	}
+	if status != "ready" {
+		t.Errorf("status = %q, want %q", status, "ready")
+	}
+}
+
+func TestReadPostmasterPidMissingOrInvalid(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, _, ok := readPostmasterPid(filepath.Join(dir, "does-not-exist")); ok {
+		t.Error("readPostmasterPid on a missing file = ok, want false")
+	}
+
+	badPath := filepath.Join(dir, "postmaster.pid")
+	if err := os.WriteFile(badPath, []byte("not-a-pid\n"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, _, ok := readPostmasterPid(badPath); ok {
+		t.Error("readPostmasterPid on a non-numeric first line = ok, want false")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if got := exitCode(nil); got != 0 {
+		t.Errorf("exitCode(nil) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,182 @@
+package pg_ctl
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const logPollInterval = 250 * time.Millisecond
+
+// TailLog follows the Controller's configured log destination, writing newly appended bytes to w
+// until ctx is done. It's a fallback for callers who'd rather watch the log than trust pg_ctl
+// start -w's exit code, which lies about readiness on PostgreSQL 10+ (see Start).
+//
+// The log file is resolved from WithLogPath if given, otherwise from log_directory/log_filename
+// in the dataDir's postgresql.conf, falling back to the most recently modified file in that
+// directory when log_filename contains an strftime placeholder TailLog can't resolve statically.
+func (p *Controller) TailLog(ctx context.Context, w io.Writer) error {
+	path, err := p.resolveLogPath()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("pg_ctl: cannot open log file %s: %v", path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(0, io.SeekEnd); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 4096)
+	for {
+		n, readErr := f.Read(buf)
+		if n > 0 {
+			if _, writeErr := w.Write(buf[:n]); writeErr != nil {
+				return writeErr
+			}
+		}
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(logPollInterval):
+		}
+	}
+}
+
+// WaitForLogPattern scans the Controller's log file for a line matching re, returning nil as soon
+// as one is found. It's typically used during startup to detect "database system is ready to
+// accept connections" or a recovery-progress message, since pg_ctl start -w's exit code alone
+// can't be trusted for readiness on PostgreSQL 10+ (see Start).
+func (p *Controller) WaitForLogPattern(ctx context.Context, re *regexp.Regexp) error {
+	path, err := p.resolveLogPath()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("pg_ctl: cannot open log file %s: %v", path, err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	for {
+		line, readErr := reader.ReadString('\n')
+		if re.MatchString(line) {
+			return nil
+		}
+		if readErr != nil {
+			if readErr != io.EOF {
+				return readErr
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(logPollInterval):
+			}
+		}
+	}
+}
+
+// resolveLogPath returns an explicit WithLogPath override, or else derives the active log file
+// from postgresql.conf.
+func (p *Controller) resolveLogPath() (string, error) {
+	if p.logPath != "" {
+		return p.logPath, nil
+	}
+
+	conf, err := readPostgresqlConf(p.dataDir)
+	if err != nil {
+		return "", fmt.Errorf("pg_ctl: cannot resolve log path: %v", err)
+	}
+
+	logDir := conf["log_directory"]
+	if logDir == "" {
+		logDir = "log"
+	}
+	if !filepath.IsAbs(logDir) {
+		logDir = filepath.Join(p.dataDir, logDir)
+	}
+
+	logFilename := conf["log_filename"]
+	if logFilename != "" && !strings.Contains(logFilename, "%") {
+		return filepath.Join(logDir, logFilename), nil
+	}
+
+	return newestFileIn(logDir)
+}
+
+// readPostgresqlConf does a best-effort parse of dataDir's postgresql.conf into a key/value map:
+// enough to resolve log_directory and log_filename, not a full GUC parser.
+func readPostgresqlConf(dataDir string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(dataDir, "postgresql.conf"))
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		if idx := strings.Index(value, "#"); idx != -1 {
+			value = value[:idx]
+		}
+		value = strings.TrimSpace(value)
+		value = strings.Trim(value, `'"`)
+		settings[strings.TrimSpace(key)] = value
+	}
+	return settings, nil
+}
+
+// newestFileIn returns the most recently modified regular file in dir, used when log_filename
+// can't be resolved statically because it contains an strftime placeholder.
+func newestFileIn(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("cannot list log directory %s: %v", dir, err)
+	}
+
+	type candidate struct {
+		path    string
+		modTime time.Time
+	}
+	var candidates []candidate
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, candidate{filepath.Join(dir, entry.Name()), info.ModTime()})
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no log files found in %s", dir)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].modTime.After(candidates[j].modTime)
+	})
+	return candidates[0].path, nil
+}
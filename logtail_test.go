@@ -0,0 +1,83 @@
+package pg_ctl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadPostgresqlConf(t *testing.T) {
+	dataDir := t.TempDir()
+	conf := `# this is a comment, skip it
+
+log_directory = 'log'          # where to write logs
+log_filename = "postgresql-%Y-%m-%d.log"
+port = 5432
+not_a_setting_line_without_equals
+`
+	if err := os.WriteFile(filepath.Join(dataDir, "postgresql.conf"), []byte(conf), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	settings, err := readPostgresqlConf(dataDir)
+	if err != nil {
+		t.Fatalf("readPostgresqlConf() error = %v", err)
+	}
+
+	want := map[string]string{
+		"log_directory": "log",
+		"log_filename":  "postgresql-%Y-%m-%d.log",
+		"port":          "5432",
+	}
+	for key, wantValue := range want {
+		if got := settings[key]; got != wantValue {
+			t.Errorf("settings[%q] = %q, want %q", key, got, wantValue)
+		}
+	}
+}
+
+func TestReadPostgresqlConfMissingFile(t *testing.T) {
+	if _, err := readPostgresqlConf(t.TempDir()); err == nil {
+		t.Error("readPostgresqlConf on a directory with no postgresql.conf = nil error, want error")
+	}
+}
+
+func TestNewestFileIn(t *testing.T) {
+	dir := t.TempDir()
+
+	older := filepath.Join(dir, "postgresql-2026-01-01.log")
+	newer := filepath.Join(dir, "postgresql-2026-01-02.log")
+	if err := os.WriteFile(older, []byte("old"), 0644); err != nil {
+		t.Fatalf("WriteFile(older): %v", err)
+	}
+	if err := os.WriteFile(newer, []byte("new"), 0644); err != nil {
+		t.Fatalf("WriteFile(newer): %v", err)
+	}
+
+	now := time.Now()
+	if err := os.Chtimes(older, now.Add(-time.Hour), now.Add(-time.Hour)); err != nil {
+		t.Fatalf("Chtimes(older): %v", err)
+	}
+	if err := os.Chtimes(newer, now, now); err != nil {
+		t.Fatalf("Chtimes(newer): %v", err)
+	}
+
+	if err := os.Mkdir(filepath.Join(dir, "subdir"), 0755); err != nil {
+		t.Fatalf("Mkdir(subdir): %v", err)
+	}
+
+	got, err := newestFileIn(dir)
+	if err != nil {
+		t.Fatalf("newestFileIn() error = %v", err)
+	}
+	if got != newer {
+		t.Errorf("newestFileIn() = %q, want %q", got, newer)
+	}
+}
+
+func TestNewestFileInEmptyDir(t *testing.T) {
+	if _, err := newestFileIn(t.TempDir()); err == nil {
+		t.Error("newestFileIn on an empty directory = nil error, want error")
+	}
+}
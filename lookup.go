@@ -0,0 +1,115 @@
+package pg_ctl
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LookupPgCtl searches common locations for a pg_ctl binary, in order:
+//
+//  1. $PG_CTL, if set, taken as a direct path to the executable
+//  2. $PGBIN, if set, taken as a directory containing pg_ctl
+//  3. exec.LookPath("pg_ctl"), i.e. $PATH
+//  4. distro-specific version-suffixed layouts: /usr/lib/postgresql/<ver>/bin (Debian/Ubuntu),
+//     /usr/pgsql-<ver>/bin (RHEL), and Homebrew Cellar installs, searched in that order. Within
+//     one layout, when multiple versions are present, the highest-numbered one wins.
+//
+// It returns a descriptive error if none of the above yields an executable file.
+func LookupPgCtl() (string, error) {
+	return lookupPostgresBinary("pg_ctl", "PG_CTL")
+}
+
+// LookupInitdb searches the same locations as LookupPgCtl, but for initdb. $INITDB plays the role
+// $PG_CTL plays for LookupPgCtl.
+func LookupInitdb() (string, error) {
+	return lookupPostgresBinary("initdb", "INITDB")
+}
+
+// lookupPostgresBinary implements the shared search order behind LookupPgCtl and LookupInitdb for
+// a given executable name, honoring an explicit-path env var and the common $PGBIN directory.
+func lookupPostgresBinary(name, explicitPathEnv string) (string, error) {
+	if path := os.Getenv(explicitPathEnv); path != "" && isExecutableFile(path) {
+		return path, nil
+	}
+	if dir := os.Getenv("PGBIN"); dir != "" {
+		if candidate := filepath.Join(dir, name); isExecutableFile(candidate) {
+			return candidate, nil
+		}
+	}
+	if path, err := exec.LookPath(name); err == nil {
+		return path, nil
+	}
+	for _, candidate := range distroCandidates(name) {
+		if isExecutableFile(candidate) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("pg_ctl: could not locate a %s binary; set $PGBIN or $%s, or pass an explicit path", name, explicitPathEnv)
+}
+
+// distroCandidates lists version-suffixed paths for the named executable used by common distros
+// and package managers, one layout after another, with each layout's matches ordered highest
+// version first.
+func distroCandidates(name string) []string {
+	var candidates []string
+	candidates = append(candidates, globSortedDesc("/usr/lib/postgresql/*/bin/"+name)...)
+	candidates = append(candidates, globSortedDesc("/usr/pgsql-*/bin/"+name)...)
+	candidates = append(candidates, globSortedDesc("/usr/local/Cellar/postgresql*/*/bin/"+name)...)
+	candidates = append(candidates, globSortedDesc("/opt/homebrew/Cellar/postgresql*/*/bin/"+name)...)
+	return candidates
+}
+
+// globSortedDesc expands pattern and orders the matches so the highest version sorts first. A
+// plain lexicographic sort gets this wrong as soon as version numbers differ in digit count (e.g.
+// "9" sorting after "16"), so paths are compared component-by-component with numeric runs
+// compared as numbers rather than strings.
+func globSortedDesc(pattern string) []string {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return versionAwareCompare(matches[i], matches[j]) > 0
+	})
+	return matches
+}
+
+var versionTokenRegex = regexp.MustCompile(`\d+|\D+`)
+
+// versionAwareCompare compares two strings token by token, where tokens are maximal runs of
+// digits or non-digits. Digit runs compare numerically (so "16" > "9"); everything else compares
+// lexicographically. It returns a negative, zero, or positive number, mirroring strings.Compare.
+func versionAwareCompare(a, b string) int {
+	aTokens := versionTokenRegex.FindAllString(a, -1)
+	bTokens := versionTokenRegex.FindAllString(b, -1)
+
+	for i := 0; i < len(aTokens) && i < len(bTokens); i++ {
+		at, bt := aTokens[i], bTokens[i]
+		aNum, aErr := strconv.Atoi(at)
+		bNum, bErr := strconv.Atoi(bt)
+		if aErr == nil && bErr == nil {
+			if aNum != bNum {
+				return aNum - bNum
+			}
+			continue
+		}
+		if at != bt {
+			return strings.Compare(at, bt)
+		}
+	}
+	return len(aTokens) - len(bTokens)
+}
+
+func isExecutableFile(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return info.Mode()&0111 != 0
+}
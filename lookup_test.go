@@ -0,0 +1,58 @@
+package pg_ctl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGlobSortedDescOrdersVersionsNumerically(t *testing.T) {
+	dir := t.TempDir()
+	for _, version := range []string{"9", "10", "16"} {
+		binDir := filepath.Join(dir, "usr/lib/postgresql", version, "bin")
+		if err := os.MkdirAll(binDir, 0755); err != nil {
+			t.Fatalf("MkdirAll: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(binDir, "pg_ctl"), nil, 0755); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+
+	got := globSortedDesc(filepath.Join(dir, "usr/lib/postgresql/*/bin/pg_ctl"))
+	want := []string{
+		filepath.Join(dir, "usr/lib/postgresql/16/bin/pg_ctl"),
+		filepath.Join(dir, "usr/lib/postgresql/10/bin/pg_ctl"),
+		filepath.Join(dir, "usr/lib/postgresql/9/bin/pg_ctl"),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("globSortedDesc(...) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("globSortedDesc(...)[%d] = %q, want %q (full: %v)", i, got[i], want[i], got)
+		}
+	}
+}
+
+func TestVersionAwareCompare(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		wantSign int
+	}{
+		{"/usr/pgsql-16/bin/pg_ctl", "/usr/pgsql-9/bin/pg_ctl", 1},
+		{"/usr/pgsql-9/bin/pg_ctl", "/usr/pgsql-16/bin/pg_ctl", -1},
+		{"/usr/pgsql-10/bin/pg_ctl", "/usr/pgsql-10/bin/pg_ctl", 0},
+	}
+	for _, c := range cases {
+		got := versionAwareCompare(c.a, c.b)
+		gotSign := 0
+		if got > 0 {
+			gotSign = 1
+		} else if got < 0 {
+			gotSign = -1
+		}
+		if gotSign != c.wantSign {
+			t.Errorf("versionAwareCompare(%q, %q) = %d, want sign %d", c.a, c.b, got, c.wantSign)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package pg_ctl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// SendSignal reads postmaster.pid from the Controller's dataDir and signals the postmaster
+// directly, bypassing pg_ctl entirely. This is a useful fallback when pg_ctl start -w's readiness
+// reporting can't be trusted (see Start) or when avoiding a subprocess matters.
+//
+// The postmaster honors the same signals pg_ctl's -m modes translate to: SIGTERM for a smart
+// shutdown, SIGINT for fast, SIGQUIT for immediate, and SIGHUP to reload configuration.
+func (p *Controller) SendSignal(sig syscall.Signal) error {
+	pid, _, ok := readPostmasterPid(filepath.Join(p.dataDir, "postmaster.pid"))
+	if !ok {
+		return fmt.Errorf("pg_ctl: cannot read postmaster.pid in %s", p.dataDir)
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return fmt.Errorf("pg_ctl: cannot find postmaster process %d: %v", pid, err)
+	}
+	return proc.Signal(sig)
+}
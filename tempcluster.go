@@ -0,0 +1,173 @@
+package pg_ctl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// TempClusterOptions configures NewTempCluster.
+type TempClusterOptions struct {
+	// BaseDir is the parent directory for the generated data and socket directories. Defaults to
+	// os.TempDir().
+	BaseDir string
+
+	// DataDir, if set, is used instead of generating a fresh one under BaseDir. Combine with
+	// Reuse to keep a persistent fixture across test runs.
+	DataDir string
+
+	// Reuse skips initdb when DataDir already exists and its PG_VERSION matches the server's
+	// major version (NewTempCluster returns an error on mismatch). When Reuse is set, Close
+	// leaves DataDir in place instead of deleting it.
+	Reuse bool
+
+	InitOptions        InitOptions
+	StartOptions       StartOptions
+	ControllerOptions  []ControllerOption
+	InitializerOptions []InitializerOption
+}
+
+// TempCluster manages a throwaway (or, with Reuse, persistent) PostgreSQL cluster for tests,
+// similar in spirit to embedded-postgres/tmp-postgres/pgfixture.
+type TempCluster struct {
+	Controller *Controller
+	DataDir    string
+	SocketDir  string
+	Port       int
+
+	reuse bool
+}
+
+// NewTempCluster allocates a socket directory and port, initializes a data directory (unless
+// Reuse applies), starts postgres against it, and waits for readiness.
+//
+// If any step fails, NewTempCluster cleans up whatever it created: if postgres actually launched
+// (Start reports a pid even when it returns ErrStartedNotReady), it's killed with an immediate
+// stop before the socket directory, and the data directory too if it generated one itself, are
+// removed. Without this, a failed construction could leave a live postmaster pointed at a data
+// directory that's about to be deleted out from under it.
+func NewTempCluster(ctx context.Context, opts TempClusterOptions) (tc *TempCluster, err error) {
+	baseDir := opts.BaseDir
+	if baseDir == "" {
+		baseDir = os.TempDir()
+	}
+
+	ownsDataDir := opts.DataDir == ""
+	dataDir := opts.DataDir
+	var socketDir string
+	var controller *Controller
+	var startedPid int
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		if controller != nil && startedPid != 0 {
+			stopCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			controller.Stop(stopCtx, StopImmediate, 30*time.Second)
+			cancel()
+		}
+		if socketDir != "" {
+			os.RemoveAll(socketDir)
+		}
+		if ownsDataDir && dataDir != "" {
+			os.RemoveAll(dataDir)
+		}
+	}()
+
+	if ownsDataDir {
+		dataDir, err = os.MkdirTemp(baseDir, "pg_ctl-data-")
+		if err != nil {
+			return nil, fmt.Errorf("pg_ctl: TempCluster: %v", err)
+		}
+	}
+
+	socketDir, err = os.MkdirTemp(baseDir, "pg_ctl-socket-")
+	if err != nil {
+		return nil, fmt.Errorf("pg_ctl: TempCluster: %v", err)
+	}
+
+	port, err := freeTCPPort()
+	if err != nil {
+		return nil, fmt.Errorf("pg_ctl: TempCluster: %v", err)
+	}
+
+	controller = NewController(dataDir, opts.ControllerOptions...)
+
+	if err = ensureInitialized(ctx, controller, dataDir, opts); err != nil {
+		return nil, err
+	}
+
+	startOpts := opts.StartOptions
+	startOpts.Options = strings.TrimSpace(fmt.Sprintf("-k %s -p %d -F %s", socketDir, port, startOpts.Options))
+	startResult, err := controller.Start(ctx, startOpts)
+	startedPid = startResult.Pid
+	if err != nil {
+		return nil, fmt.Errorf("pg_ctl: TempCluster: %w", err)
+	}
+
+	return &TempCluster{
+		Controller: controller,
+		DataDir:    dataDir,
+		SocketDir:  socketDir,
+		Port:       port,
+		reuse:      opts.Reuse,
+	}, nil
+}
+
+// ensureInitialized runs initdb into dataDir unless opts.Reuse is set and dataDir already holds a
+// data directory matching the server's major version.
+func ensureInitialized(ctx context.Context, controller *Controller, dataDir string, opts TempClusterOptions) error {
+	if opts.Reuse {
+		if existing, ok := readPGVersionFile(dataDir); ok {
+			major, _, err := controller.Version()
+			if err != nil {
+				return fmt.Errorf("pg_ctl: TempCluster: %w", err)
+			}
+			if existing != major {
+				return fmt.Errorf("pg_ctl: TempCluster: data dir %s is PostgreSQL %d, server is %d", dataDir, existing, major)
+			}
+			return nil
+		}
+	}
+
+	initOpts := opts.InitOptions
+	initOpts.DataDir = dataDir
+	initializer := NewInitializer(opts.InitializerOptions...)
+	if err := initializer.Init(ctx, initOpts, io.Discard, io.Discard); err != nil {
+		return fmt.Errorf("pg_ctl: TempCluster: %w", err)
+	}
+	return nil
+}
+
+// ConnString returns a libpq connection string for connecting through the cluster's Unix socket.
+func (tc *TempCluster) ConnString() string {
+	return fmt.Sprintf("host=%s port=%d sslmode=disable", tc.SocketDir, tc.Port)
+}
+
+// Close issues a fast shutdown and removes the socket directory. DataDir is also removed unless
+// the cluster was created with Reuse, in which case it's left in place as a fixture for the next
+// run.
+func (tc *TempCluster) Close(ctx context.Context) error {
+	_, stopErr := tc.Controller.Stop(ctx, StopFast, 30*time.Second)
+
+	os.RemoveAll(tc.SocketDir)
+	if !tc.reuse {
+		os.RemoveAll(tc.DataDir)
+	}
+
+	return stopErr
+}
+
+func freeTCPPort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,143 @@
+package pg_ctl
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnsureInitializedSkipsInitdbWhenReuseMatches(t *testing.T) {
+	dataDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dataDir, "PG_VERSION"), []byte("16\n"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	controller := NewController(dataDir, WithPGVersion(16))
+	opts := TempClusterOptions{
+		Reuse: true,
+		// If ensureInitialized mistakenly tries to run initdb despite the version match, this
+		// nonexistent binary makes that failure visible instead of silently succeeding.
+		InitializerOptions: []InitializerOption{WithInitdbBinary(filepath.Join(dataDir, "no-such-initdb"))},
+	}
+
+	if err := ensureInitialized(context.Background(), controller, dataDir, opts); err != nil {
+		t.Fatalf("ensureInitialized() = %v, want nil", err)
+	}
+}
+
+func TestEnsureInitializedErrorsOnReuseMismatch(t *testing.T) {
+	dataDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dataDir, "PG_VERSION"), []byte("14\n"), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	controller := NewController(dataDir, WithPGVersion(16))
+	opts := TempClusterOptions{Reuse: true}
+
+	err := ensureInitialized(context.Background(), controller, dataDir, opts)
+	if err == nil {
+		t.Fatal("ensureInitialized() = nil, want version-mismatch error")
+	}
+	if !strings.Contains(err.Error(), "14") || !strings.Contains(err.Error(), "16") {
+		t.Errorf("ensureInitialized() error %q does not mention both versions", err)
+	}
+}
+
+func TestEnsureInitializedRunsInitdbWhenNotReusing(t *testing.T) {
+	dir := t.TempDir()
+	dataDir := filepath.Join(dir, "data")
+	argsLog := filepath.Join(dir, "args.log")
+	initdb := writeFakeInitdb(t, argsLog, "16")
+
+	controller := NewController(dataDir, WithPGVersion(16))
+	opts := TempClusterOptions{
+		InitializerOptions: []InitializerOption{WithInitdbBinary(initdb)},
+	}
+
+	if err := ensureInitialized(context.Background(), controller, dataDir, opts); err != nil {
+		t.Fatalf("ensureInitialized() = %v, want nil", err)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, "PG_VERSION")); err != nil {
+		t.Errorf("initdb was not run: %v", err)
+	}
+}
+
+// writeFakePgCtlStartedNotReady writes a shell script standing in for pg_ctl: `start` writes a
+// postmaster.pid with a pid but no "ready" status line and exits non-zero (simulating PG10+'s
+// "launched but not ready before -t elapsed" case), and `stop` records that it ran (to stopMarker)
+// and exits 0, so tests can assert NewTempCluster's cleanup actually attempts a stop.
+func writeFakePgCtlStartedNotReady(t *testing.T, stopMarker string) string {
+	t.Helper()
+	script := filepath.Join(t.TempDir(), "fake-pg_ctl")
+	contents := `#!/bin/sh
+cmd="$1"
+shift
+datadir=""
+while [ "$#" -gt 0 ]; do
+  case "$1" in
+    -D) shift; datadir="$1" ;;
+  esac
+  shift
+done
+case "$cmd" in
+  start)
+    printf '99999\n%s\n0\n0\n\n\n0\nstarting\n' "$datadir" > "$datadir/postmaster.pid"
+    exit 1
+    ;;
+  stop)
+    touch "` + stopMarker + `"
+    exit 0
+    ;;
+  --version)
+    echo "pg_ctl (PostgreSQL) 16.2"
+    ;;
+esac
+`
+	if err := os.WriteFile(script, []byte(contents), 0755); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return script
+}
+
+func TestNewTempClusterCleansUpAndStopsOnStartFailure(t *testing.T) {
+	baseDir := t.TempDir()
+	stopMarker := filepath.Join(baseDir, "stopped")
+
+	argsLog := filepath.Join(baseDir, "initdb-args.log")
+	initdb := writeFakeInitdb(t, argsLog, "16")
+	pgCtl := writeFakePgCtlStartedNotReady(t, stopMarker)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	tc, err := NewTempCluster(ctx, TempClusterOptions{
+		BaseDir:            baseDir,
+		ControllerOptions:  []ControllerOption{WithBinary(pgCtl), WithPGVersion(16)},
+		InitializerOptions: []InitializerOption{WithInitdbBinary(initdb)},
+		StartOptions:       StartOptions{Timeout: 500 * time.Millisecond},
+	})
+	if err == nil {
+		t.Fatalf("NewTempCluster() = %+v, nil, want an error", tc)
+	}
+	if !errors.Is(err, ErrStartedNotReady) && !errors.Is(err, ErrStartFailed) {
+		t.Errorf("NewTempCluster() error = %v, want ErrStartedNotReady or ErrStartFailed", err)
+	}
+
+	if _, statErr := os.Stat(stopMarker); statErr != nil {
+		t.Errorf("Stop was not attempted during cleanup: %v", statErr)
+	}
+
+	entries, readErr := os.ReadDir(baseDir)
+	if readErr != nil {
+		t.Fatalf("ReadDir(baseDir): %v", readErr)
+	}
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "pg_ctl-data-") || strings.HasPrefix(e.Name(), "pg_ctl-socket-") {
+			t.Errorf("leftover temp dir after failed NewTempCluster: %s", e.Name())
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package pg_ctl
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var pgCtlVersionRegex = regexp.MustCompile(`(\d+)(?:\.(\d+))?`)
+
+// Version reports the server's major and minor version by parsing `pg_ctl --version`, e.g.
+// "pg_ctl (PostgreSQL) 14.5" yields (14, 5). Only the first two numbers are read, so a pre-10
+// "9.6.24" yields (9, 6); a version with no second number, such as a bare "10", yields minor 0.
+//
+// If the Controller was built with WithPGVersion, that value is returned directly and pg_ctl is
+// never invoked.
+func (p *Controller) Version() (major, minor int, err error) {
+	if p.pgVersionMajor != 0 {
+		return p.pgVersionMajor, 0, nil
+	}
+
+	binary, err := p.binaryPath()
+	if err != nil {
+		return 0, 0, err
+	}
+	out, err := exec.Command(binary, "--version").Output()
+	if err != nil {
+		return 0, 0, fmt.Errorf("pg_ctl: cannot determine version: %v", err)
+	}
+	return parsePgCtlVersion(out)
+}
+
+func parsePgCtlVersion(out []byte) (major, minor int, err error) {
+	matches := pgCtlVersionRegex.FindSubmatch(out)
+	if matches == nil {
+		return 0, 0, fmt.Errorf("pg_ctl: cannot parse version from %q", out)
+	}
+	major, _ = strconv.Atoi(string(matches[1]))
+	if len(matches[2]) > 0 {
+		minor, _ = strconv.Atoi(string(matches[2]))
+	}
+	return major, minor, nil
+}
+
+// majorVersion resolves the server's major version for readiness decisions in Start: an explicit
+// WithPGVersion override, else PG_VERSION in dataDir, else a `pg_ctl --version` fallback for a
+// not-yet-initialized dataDir.
+func (p *Controller) majorVersion() (int, error) {
+	if p.pgVersionMajor != 0 {
+		return p.pgVersionMajor, nil
+	}
+	if major, ok := readPGVersionFile(p.dataDir); ok {
+		return major, nil
+	}
+
+	major, _, err := p.Version()
+	return major, err
+}
+
+// readPGVersionFile reads and parses the PG_VERSION marker file in dataDir, returning false if
+// it's missing or unparseable (e.g. dataDir hasn't been initialized yet). PG_VERSION holds
+// "9.6"-style strings before PostgreSQL 10 and a bare major version ("10", "14", ...) from 10
+// onward.
+func readPGVersionFile(dataDir string) (major int, ok bool) {
+	data, err := os.ReadFile(filepath.Join(dataDir, "PG_VERSION"))
+	if err != nil {
+		return 0, false
+	}
+	s := strings.TrimSpace(string(data))
+	if dot := strings.Index(s, "."); dot != -1 {
+		s = s[:dot]
+	}
+	major, err = strconv.Atoi(s)
+	return major, err == nil
+}
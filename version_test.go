@@ -0,0 +1,65 @@
+package pg_ctl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePgCtlVersion(t *testing.T) {
+	cases := []struct {
+		out        string
+		wantMajor  int
+		wantMinor  int
+		wantErrStr bool
+	}{
+		{"pg_ctl (PostgreSQL) 14.5\n", 14, 5, false},
+		{"pg_ctl (PostgreSQL) 9.6.24\n", 9, 6, false},
+		{"pg_ctl (PostgreSQL) 16.2\n", 16, 2, false},
+		{"not a version string\n", 0, 0, true},
+	}
+	for _, c := range cases {
+		major, minor, err := parsePgCtlVersion([]byte(c.out))
+		if c.wantErrStr {
+			if err == nil {
+				t.Errorf("parsePgCtlVersion(%q) = (%d, %d, nil), want error", c.out, major, minor)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parsePgCtlVersion(%q) returned error: %v", c.out, err)
+			continue
+		}
+		if major != c.wantMajor || minor != c.wantMinor {
+			t.Errorf("parsePgCtlVersion(%q) = (%d, %d), want (%d, %d)", c.out, major, minor, c.wantMajor, c.wantMinor)
+		}
+	}
+}
+
+func TestReadPGVersionFile(t *testing.T) {
+	cases := []struct {
+		content   string
+		wantMajor int
+	}{
+		{"16\n", 16},
+		{"9.6\n", 9},
+		{"10\n", 10},
+	}
+	for _, c := range cases {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "PG_VERSION"), []byte(c.content), 0644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+		major, ok := readPGVersionFile(dir)
+		if !ok {
+			t.Fatalf("readPGVersionFile(%q) = (_, false), want ok", c.content)
+		}
+		if major != c.wantMajor {
+			t.Errorf("readPGVersionFile(%q) = %d, want %d", c.content, major, c.wantMajor)
+		}
+	}
+
+	if _, ok := readPGVersionFile(t.TempDir()); ok {
+		t.Error("readPGVersionFile on a dir with no PG_VERSION = ok, want false")
+	}
+}